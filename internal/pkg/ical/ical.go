@@ -0,0 +1,134 @@
+// Package ical implements a minimal parser for the RFC 5545 iCalendar format, extracting just
+// enough of a feed's VEVENTs to be used as scheduling windows elsewhere.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	dateTimeLayout    = "20060102T150405"
+	dateTimeUTCLayout = "20060102T150405Z"
+	dateOnlyLayout    = "20060102"
+)
+
+// Event is a single VEVENT occurrence parsed from an iCalendar feed.
+type Event struct {
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// Parse parses the VEVENTs out of an RFC 5545 iCalendar feed.
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ics feed: %w", err)
+	}
+
+	var events []Event
+
+	var current *Event
+
+	for _, line := range lines {
+		switch {
+		case line == "BEGIN:VEVENT":
+			current = &Event{}
+		case line == "END:VEVENT":
+			if current == nil {
+				continue
+			}
+
+			events = append(events, *current)
+			current = nil
+		case current != nil:
+			if err := current.applyLine(line); err != nil {
+				return nil, fmt.Errorf("failed to parse VEVENT: %w", err)
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// unfoldLines reads an ics feed and undoes RFC 5545 line folding, where a line starting with a
+// space or tab is a continuation of the previous line.
+func unfoldLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// applyLine applies a single "NAME[;PARAMS]:VALUE" content line to the event being built.
+func (e *Event) applyLine(line string) error {
+	name, value, found := strings.Cut(line, ":")
+	if !found {
+		return nil
+	}
+
+	property, params, _ := strings.Cut(name, ";")
+
+	switch property {
+	case "SUMMARY":
+		e.Summary = value
+	case "DTSTART":
+		start, err := parseICalTime(value, params)
+		if err != nil {
+			return fmt.Errorf("failed to parse DTSTART: %w", err)
+		}
+
+		e.Start = start
+	case "DTEND":
+		end, err := parseICalTime(value, params)
+		if err != nil {
+			return fmt.Errorf("failed to parse DTEND: %w", err)
+		}
+
+		e.End = end
+	}
+
+	return nil
+}
+
+// parseICalTime parses a DTSTART/DTEND value, honoring a TZID parameter, a trailing "Z" for UTC,
+// or a date-only (all day) value.
+func parseICalTime(value, params string) (time.Time, error) {
+	if strings.Contains(params, "VALUE=DATE") && !strings.Contains(value, "T") {
+		return time.ParseInLocation(dateOnlyLayout, value, time.UTC)
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(dateTimeUTCLayout, value)
+	}
+
+	location := time.UTC
+
+	if _, tzid, found := strings.Cut(params, "TZID="); found {
+		loc, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to load timezone %q: %w", tzid, err)
+		}
+
+		location = loc
+	}
+
+	return time.ParseInLocation(dateTimeLayout, value, location)
+}
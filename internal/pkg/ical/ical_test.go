@@ -0,0 +1,71 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sampleFeed = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Christmas break\r\n" +
+	"DTSTART:20241224T000000Z\r\n" +
+	"DTEND:20241227T000000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Company-wide offsite\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20240610T090000\r\n" +
+	"DTEND;TZID=Europe/Berlin:20240611T180000\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Public holiday\r\n" +
+	"DTSTART;VALUE=DATE:20240101\r\n" +
+	"DTEND;VALUE=DATE:20240102\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParse(t *testing.T) {
+	events, err := Parse(strings.NewReader(sampleFeed))
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+
+	assert.Equal(t, "Christmas break", events[0].Summary)
+	assert.Equal(t, time.Date(2024, time.December, 24, 0, 0, 0, 0, time.UTC), events[0].Start)
+	assert.Equal(t, time.Date(2024, time.December, 27, 0, 0, 0, 0, time.UTC), events[0].End)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+	assert.Equal(t, "Company-wide offsite", events[1].Summary)
+	assert.Equal(t, time.Date(2024, time.June, 10, 9, 0, 0, 0, berlin), events[1].Start)
+	assert.Equal(t, time.Date(2024, time.June, 11, 18, 0, 0, 0, berlin), events[1].End)
+
+	assert.Equal(t, "Public holiday", events[2].Summary)
+	assert.Equal(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), events[2].Start)
+	assert.Equal(t, time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC), events[2].End)
+}
+
+func TestParse_foldedLine(t *testing.T) {
+	feed := "BEGIN:VEVENT\r\n" +
+		"SUMMARY:A very long summary that got \r\n" +
+		" folded onto a continuation line\r\n" +
+		"DTSTART:20240101T000000Z\r\n" +
+		"DTEND:20240102T000000Z\r\n" +
+		"END:VEVENT\r\n"
+
+	events, err := Parse(strings.NewReader(feed))
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "A very long summary that got folded onto a continuation line", events[0].Summary)
+}
+
+func TestParse_invalidTimezone(t *testing.T) {
+	feed := "BEGIN:VEVENT\r\n" +
+		"DTSTART;TZID=Not/Areal:20240101T000000\r\n" +
+		"END:VEVENT\r\n"
+
+	_, err := Parse(strings.NewReader(feed))
+	assert.Error(t, err)
+}
@@ -0,0 +1,270 @@
+package values
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronShortcuts maps the common "@" shortcuts to their equivalent 5-field cron expression.
+var cronShortcuts = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// cronDayOfWeekNames mirrors weekdayNames (shared with relativeTimeSpan) as plain ints, since
+// cron fields are compared and range-walked as ints rather than time.Weekday.
+var cronDayOfWeekNames = func() map[string]int {
+	names := make(map[string]int, len(weekdayNames))
+	for name, weekday := range weekdayNames {
+		names[name] = int(weekday)
+	}
+
+	return names
+}()
+
+var monthNames = map[string]int{
+	"Jan": 1,
+	"Feb": 2,
+	"Mar": 3,
+	"Apr": 4,
+	"May": 5,
+	"Jun": 6,
+	"Jul": 7,
+	"Aug": 8,
+	"Sep": 9,
+	"Oct": 10,
+	"Nov": 11,
+	"Dec": 12,
+}
+
+// cronTimeSpan is a timespan described by a standard 5-field cron expression plus a timezone,
+// e.g. "*/15 9-17 * * 1-5 Europe/Berlin". Each field is precomputed into a bitset of the values
+// it matches, so isTimeInSpan is a handful of slice lookups instead of a re-parse.
+type cronTimeSpan struct {
+	timezone      *time.Location
+	minute        []bool // index 0-59
+	hour          []bool // index 0-23
+	dayOfMonth    []bool // index 0-31, 0 unused
+	month         []bool // index 0-12, 0 unused
+	dayOfWeek     []bool // index 0-6, Sunday = 0
+	domRestricted bool
+	dowRestricted bool
+}
+
+// parseCronTimeSpan parses a cron timespan of the form "<cron expression> Timezone", where the
+// cron expression is either a standard 5-field expression or one of the @hourly/@daily/@weekly/
+// @monthly shortcuts.
+func parseCronTimeSpan(timespanString string) (*cronTimeSpan, error) {
+	fields := strings.Fields(timespanString)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("invalid cron timespan %q: missing timezone", timespanString)
+	}
+
+	timezone, err := time.LoadLocation(fields[len(fields)-1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse timezone: %w", err)
+	}
+
+	cronFields := fields[:len(fields)-1]
+
+	if len(cronFields) == 1 {
+		shortcut, ok := cronShortcuts[cronFields[0]]
+		if !ok {
+			return nil, fmt.Errorf("invalid cron shortcut %q", cronFields[0])
+		}
+
+		cronFields = strings.Fields(shortcut)
+	}
+
+	if len(cronFields) != 5 {
+		return nil, fmt.Errorf(
+			"invalid cron expression %q: expected 5 fields or an @ shortcut, got %d fields",
+			strings.Join(cronFields, " "),
+			len(cronFields),
+		)
+	}
+
+	minute, err := parseCronField(cronFields[0], 0, 59, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse minute field: %w", err)
+	}
+
+	hour, err := parseCronField(cronFields[1], 0, 23, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse hour field: %w", err)
+	}
+
+	dayOfMonth, err := parseCronField(cronFields[2], 1, 31, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse day of month field: %w", err)
+	}
+
+	month, err := parseCronField(cronFields[3], 1, 12, monthNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse month field: %w", err)
+	}
+
+	dayOfWeek, err := parseCronField(cronFields[4], 0, 6, cronDayOfWeekNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse day of week field: %w", err)
+	}
+
+	return &cronTimeSpan{
+		timezone:      timezone,
+		minute:        minute,
+		hour:          hour,
+		dayOfMonth:    dayOfMonth,
+		month:         month,
+		dayOfWeek:     dayOfWeek,
+		domRestricted: cronFields[2] != "*",
+		dowRestricted: cronFields[4] != "*",
+	}, nil
+}
+
+// isTimeInSpan checks if timestamp falls within the cron timespan, once converted to its
+// configured timezone. When both day-of-month and day-of-week are restricted (not "*"), the
+// classic cron rule applies: the timespan matches if either field matches.
+func (t cronTimeSpan) isTimeInSpan(timestamp time.Time) bool {
+	local := timestamp.In(t.timezone)
+
+	if !t.minute[local.Minute()] || !t.hour[local.Hour()] || !t.month[int(local.Month())] {
+		return false
+	}
+
+	domMatch := t.dayOfMonth[local.Day()]
+	dowMatch := t.dayOfWeek[int(local.Weekday())]
+
+	switch {
+	case t.domRestricted && t.dowRestricted:
+		return domMatch || dowMatch
+	case t.domRestricted:
+		return domMatch
+	case t.dowRestricted:
+		return dowMatch
+	default:
+		return true
+	}
+}
+
+// NextTransition finds the next time the cron timespan starts or stops matching, by sampling
+// minute boundaries forward from now within a bounded lookahead window.
+func (t cronTimeSpan) NextTransition(now time.Time) (time.Time, bool) {
+	return genericNextTransition(t, now)
+}
+
+// parseCronField parses a single, comma-separated cron field into a bitset covering [min, max],
+// supporting "*", lists ("a,b,c"), ranges ("a-b", wrapping past max back to min), steps ("*/n",
+// "a-b/n"), and, if names is non-nil, named values (e.g. "Jul", "Sun").
+func parseCronField(field string, min, max int, names map[string]int) ([]bool, error) {
+	bitset := make([]bool, max+1)
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step, err := splitCronStep(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %q: %w", field, err)
+		}
+
+		from, to := min, max
+		if rangePart != "*" {
+			from, to, err = parseCronRange(rangePart, min, max, names)
+			if err != nil {
+				return nil, fmt.Errorf("invalid field %q: %w", field, err)
+			}
+		}
+
+		setCronRange(bitset, from, to, step, min, max)
+	}
+
+	return bitset, nil
+}
+
+// splitCronStep splits a field part into its range/wildcard portion and step, e.g. "1-10/2"
+// becomes ("1-10", 2) and "*/15" becomes ("*", 15). A part without a step defaults to step 1.
+func splitCronStep(part string) (rangePart string, step int, err error) {
+	before, after, found := strings.Cut(part, "/")
+	if !found {
+		return part, 1, nil
+	}
+
+	step, err = strconv.Atoi(after)
+	if err != nil || step <= 0 {
+		return "", 0, fmt.Errorf("invalid step %q", after)
+	}
+
+	return before, step, nil
+}
+
+// parseCronRange parses a range part such as "1-5" or a single value such as "5"/"Jul" into its
+// inclusive [from, to] bounds.
+func parseCronRange(rangePart string, min, max int, names map[string]int) (from, to int, err error) {
+	before, after, found := strings.Cut(rangePart, "-")
+	if !found {
+		value, err := parseCronValue(rangePart, min, max, names)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		return value, value, nil
+	}
+
+	from, err = parseCronValue(before, min, max, names)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to, err = parseCronValue(after, min, max, names)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return from, to, nil
+}
+
+// parseCronValue parses a single field value, either a name (if names is non-nil and contains
+// it) or an integer, and validates it against [min, max]. Day-of-week's conventional "7" (an
+// alternate name for Sunday) is normalized to 0.
+func parseCronValue(token string, min, max int, names map[string]int) (int, error) {
+	if names != nil {
+		if value, ok := names[token]; ok {
+			return value, nil
+		}
+	}
+
+	value, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", token)
+	}
+
+	if max == 6 && value == 7 {
+		value = 0
+	}
+
+	if value < min || value > max {
+		return 0, fmt.Errorf("value %q out of range [%d, %d]", token, min, max)
+	}
+
+	return value, nil
+}
+
+// setCronRange marks every step-th value of bitset in the inclusive range [from, to], wrapping
+// around past max back to min if from is after to (e.g. a day-of-week range of "Fri-Mon").
+func setCronRange(bitset []bool, from, to, step, min, max int) {
+	for count, v := 0, from; ; count++ {
+		if count%step == 0 {
+			bitset[v] = true
+		}
+
+		if v == to {
+			break
+		}
+
+		v++
+		if v > max {
+			v = min
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package values
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCronTimeSpan_errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		timespanString string
+	}{
+		{name: "missing timezone", timespanString: "*/15 9-17 * * 1-5"},
+		{name: "invalid timezone", timespanString: "*/15 9-17 * * 1-5 Invalid"},
+		{name: "wrong field count", timespanString: "*/15 9-17 * 1-5 UTC"},
+		{name: "unknown shortcut", timespanString: "@yearly UTC"},
+		{name: "out of range minute", timespanString: "60 * * * * UTC"},
+		{name: "invalid step", timespanString: "*/0 * * * * UTC"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotResult, gotErr := parseCronTimeSpan(test.timespanString)
+			assert.Error(t, gotErr)
+			assert.Nil(t, gotResult)
+		})
+	}
+}
+
+func TestCronTimeSpan_isTimeInSpan(t *testing.T) {
+	tests := []struct {
+		name           string
+		timespanString string
+		time           time.Time
+		wantResult     bool
+	}{
+		{
+			name:           "every 15 minutes during business hours",
+			timespanString: "*/15 9-17 * * 1-5 Europe/Berlin",
+			time:           time.Date(2024, time.July, 22, 13, 30, 0, 0, time.UTC), // Monday, 15:30 Berlin
+			wantResult:     true,
+		},
+		{
+			name:           "off the 15 minute step",
+			timespanString: "*/15 9-17 * * 1-5 Europe/Berlin",
+			time:           time.Date(2024, time.July, 22, 13, 31, 0, 0, time.UTC),
+			wantResult:     false,
+		},
+		{
+			name:           "outside business hours",
+			timespanString: "*/15 9-17 * * 1-5 Europe/Berlin",
+			time:           time.Date(2024, time.July, 22, 18, 0, 0, 0, time.UTC), // Monday, 20:00 Berlin
+			wantResult:     false,
+		},
+		{
+			name:           "weekend excluded",
+			timespanString: "*/15 9-17 * * 1-5 Europe/Berlin",
+			time:           time.Date(2024, time.July, 20, 13, 0, 0, 0, time.UTC), // Saturday
+			wantResult:     false,
+		},
+		{
+			name:           "named month and weekday",
+			timespanString: "0 9 * Jul Mon UTC",
+			time:           time.Date(2024, time.July, 22, 9, 0, 0, 0, time.UTC), // Monday in July
+			wantResult:     true,
+		},
+		{
+			name:           "named month mismatch",
+			timespanString: "0 9 * Jul Mon UTC",
+			time:           time.Date(2024, time.August, 26, 9, 0, 0, 0, time.UTC), // Monday in August
+			wantResult:     false,
+		},
+		{
+			name:           "dom or dow when both restricted",
+			timespanString: "0 0 1 * Mon UTC",
+			time:           time.Date(2024, time.July, 15, 0, 0, 0, 0, time.UTC), // Monday, not the 1st
+			wantResult:     true,
+		},
+		{
+			name:           "neither dom nor dow matches",
+			timespanString: "0 0 1 * Mon UTC",
+			time:           time.Date(2024, time.July, 16, 0, 0, 0, 0, time.UTC), // Tuesday the 16th
+			wantResult:     false,
+		},
+		{
+			name:           "hourly shortcut",
+			timespanString: "@hourly UTC",
+			time:           time.Date(2024, time.July, 16, 5, 0, 0, 0, time.UTC),
+			wantResult:     true,
+		},
+		{
+			name:           "hourly shortcut off the hour",
+			timespanString: "@hourly UTC",
+			time:           time.Date(2024, time.July, 16, 5, 1, 0, 0, time.UTC),
+			wantResult:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			timespan, err := parseCronTimeSpan(test.timespanString)
+			assert.NoError(t, err)
+
+			gotResult := timespan.isTimeInSpan(test.time)
+			assert.Equal(t, test.wantResult, gotResult)
+		})
+	}
+}
+
+func TestAreTimespanOverlapped_cron(t *testing.T) {
+	cronA, err := parseCronTimeSpan("0 9 * * 1-5 UTC")
+	assert.NoError(t, err)
+
+	cronB, err := parseCronTimeSpan("0 9 * * Mon UTC")
+	assert.NoError(t, err)
+
+	cronC, err := parseCronTimeSpan("0 22 * * Mon UTC")
+	assert.NoError(t, err)
+
+	assert.True(t, areTimespanOverlapped(*cronA, *cronB))
+	assert.False(t, areTimespanOverlapped(*cronA, *cronC))
+}
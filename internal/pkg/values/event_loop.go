@@ -0,0 +1,63 @@
+package values
+
+import "time"
+
+// genericNextTransition is the fallback NextTransition for timespan types without a precise,
+// closed-form implementation: it samples minute boundaries forward from now, within the same
+// bounded lookahead window used by sampledOverlap, and returns the first one at which
+// isTimeInSpan's result differs from its value at now.
+func genericNextTransition(span TimeSpan, now time.Time) (time.Time, bool) {
+	state := span.isTimeInSpan(now)
+	end := now.Add(overlapSampleWindow)
+
+	for t := now.Truncate(overlapSampleStep).Add(overlapSampleStep); t.Before(end); t = t.Add(overlapSampleStep) {
+		if span.isTimeInSpan(t) != state {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// NextTransition returns the earliest upcoming transition across all of the given spans, if any.
+func NextTransition(now time.Time, spans []TimeSpan) (time.Time, bool) {
+	var earliest time.Time
+
+	found := false
+
+	for _, span := range spans {
+		transition, ok := span.NextTransition(now)
+		if !ok {
+			continue
+		}
+
+		if !found || transition.Before(earliest) {
+			earliest = transition
+			found = true
+		}
+	}
+
+	return earliest, found
+}
+
+// SleepDuration computes how long an event-driven scaling loop should sleep before reconciling
+// again: until the next span transition, or maxInterval, whichever is sooner. This bounds the
+// loop's reaction time to maxInterval even when no transition is upcoming (or spans is empty),
+// while letting it react to a transition immediately rather than waiting out the full interval.
+func SleepDuration(now time.Time, spans []TimeSpan, maxInterval time.Duration) time.Duration {
+	next, ok := NextTransition(now, spans)
+	if !ok {
+		return maxInterval
+	}
+
+	wait := next.Sub(now)
+	if wait < 0 {
+		return 0
+	}
+
+	if wait < maxInterval {
+		return wait
+	}
+
+	return maxInterval
+}
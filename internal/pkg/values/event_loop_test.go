@@ -0,0 +1,231 @@
+package values
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelativeTimeSpan_NextTransition(t *testing.T) {
+	span, err := parseRelativeTimeSpan("Mon-Fri 09:00-17:00 UTC")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		wantTime time.Time
+		wantOk   bool
+	}{
+		{
+			name:     "before start same day",
+			now:      time.Date(2024, time.January, 8, 8, 0, 0, 0, time.UTC), // Monday
+			wantTime: time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "during span transitions to end",
+			now:      time.Date(2024, time.January, 8, 12, 0, 0, 0, time.UTC),
+			wantTime: time.Date(2024, time.January, 8, 17, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "friday evening transitions to monday morning",
+			now:      time.Date(2024, time.January, 12, 18, 0, 0, 0, time.UTC), // Friday
+			wantTime: time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC),  // Monday
+			wantOk:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotTime, gotOk := span.NextTransition(test.now)
+			assert.Equal(t, test.wantOk, gotOk)
+			assert.True(t, test.wantTime.Equal(gotTime), "want %v, got %v", test.wantTime, gotTime)
+		})
+	}
+}
+
+func TestRelativeTimeSpan_NextTransition_overnightWeekdayWrap(t *testing.T) {
+	// The time-of-day range wraps past midnight, so the weekday range's boundary at Friday
+	// midnight (leaving Mon-Fri) doesn't coincide with a timeFrom/timeTo boundary and needs its
+	// own candidate, or NextTransition skips straight past it to Saturday's 06:00 end-of-range.
+	span, err := parseRelativeTimeSpan("Mon-Fri 22:00-06:00 UTC")
+	assert.NoError(t, err)
+
+	now := time.Date(2024, time.January, 12, 23, 0, 0, 0, time.UTC)     // Friday 23:00
+	wantTime := time.Date(2024, time.January, 13, 0, 0, 0, 0, time.UTC) // Saturday 00:00
+
+	assert.True(t, span.isTimeInSpan(now))
+
+	gotTime, gotOk := span.NextTransition(now)
+	assert.True(t, gotOk)
+	assert.True(t, wantTime.Equal(gotTime), "want %v, got %v", wantTime, gotTime)
+	assert.False(t, span.isTimeInSpan(gotTime))
+}
+
+func TestAbsoluteTimeSpan_NextTransition(t *testing.T) {
+	span := absoluteTimeSpan{
+		from: time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+		to:   time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		wantTime time.Time
+		wantOk   bool
+	}{
+		{
+			name:     "before window",
+			now:      time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC),
+			wantTime: time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "during window",
+			now:      time.Date(2024, time.June, 5, 0, 0, 0, 0, time.UTC),
+			wantTime: time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:   "after window",
+			now:    time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC),
+			wantOk: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotTime, gotOk := span.NextTransition(test.now)
+			assert.Equal(t, test.wantOk, gotOk)
+
+			if test.wantOk {
+				assert.True(t, test.wantTime.Equal(gotTime), "want %v, got %v", test.wantTime, gotTime)
+			}
+		})
+	}
+}
+
+func TestRelativeTimeSpan_NextTransition_dstSpringForward(t *testing.T) {
+	// In Europe/Berlin, clocks jump from 01:59:59 to 03:00:00 on the last Sunday of March, so
+	// 02:30 on that day does not exist locally. A span starting at 02:30 should still resolve
+	// to the next wall-clock moment that does exist.
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	span, err := parseRelativeTimeSpan("Mon-Sun 02:30-03:30 Europe/Berlin")
+	assert.NoError(t, err)
+
+	now := time.Date(2024, time.March, 31, 1, 0, 0, 0, berlin) // before the spring-forward gap
+
+	gotTime, gotOk := span.NextTransition(now)
+	assert.True(t, gotOk)
+	assert.True(t, gotTime.After(now))
+}
+
+func TestRelativeTimeSpan_NextTransition_dstFallBack(t *testing.T) {
+	// In Europe/Berlin, clocks fall back from 02:59:59 to 02:00:00 on the last Sunday of
+	// October, so 02:30 occurs twice locally. NextTransition must still find a real instant.
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	span, err := parseRelativeTimeSpan("Mon-Sun 02:30-03:30 Europe/Berlin")
+	assert.NoError(t, err)
+
+	now := time.Date(2024, time.October, 27, 1, 0, 0, 0, berlin) // before the repeated hour
+
+	gotTime, gotOk := span.NextTransition(now)
+	assert.True(t, gotOk)
+	assert.True(t, gotTime.After(now))
+	assert.True(t, span.isTimeInSpan(gotTime))
+}
+
+func TestCronTimeSpan_NextTransition(t *testing.T) {
+	span, err := parseCronTimeSpan("0 9 * * 1-5 UTC")
+	assert.NoError(t, err)
+
+	now := time.Date(2024, time.January, 8, 8, 0, 0, 0, time.UTC) // Monday
+	wantTime := time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC)
+
+	gotTime, gotOk := span.NextTransition(now)
+	assert.True(t, gotOk)
+	assert.True(t, wantTime.Equal(gotTime), "want %v, got %v", wantTime, gotTime)
+}
+
+func TestWeeklyTimeSpan_NextTransition(t *testing.T) {
+	span, err := parseWeeklyTimeSpan("Mon=09:00-17:00 UTC")
+	assert.NoError(t, err)
+
+	now := time.Date(2024, time.January, 8, 8, 0, 0, 0, time.UTC) // Monday
+	wantTime := time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC)
+
+	gotTime, gotOk := span.NextTransition(now)
+	assert.True(t, gotOk)
+	assert.True(t, wantTime.Equal(gotTime), "want %v, got %v", wantTime, gotTime)
+}
+
+func TestRecurringAbsoluteTimeSpan_NextTransition(t *testing.T) {
+	span, err := parseRecurringAbsoluteTimeSpan(
+		"2024-01-01T00:00Z/2024-01-02T00:00Z;FREQ=WEEKLY;COUNT=3",
+	)
+	assert.NoError(t, err)
+
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	wantTime := time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)
+
+	gotTime, gotOk := span.NextTransition(now)
+	assert.True(t, gotOk)
+	assert.True(t, wantTime.Equal(gotTime), "want %v, got %v", wantTime, gotTime)
+}
+
+func TestNextTransition_aggregate(t *testing.T) {
+	first, err := parseRelativeTimeSpan("Mon-Fri 09:00-17:00 UTC")
+	assert.NoError(t, err)
+
+	second := absoluteTimeSpan{
+		from: time.Date(2024, time.January, 8, 10, 0, 0, 0, time.UTC),
+		to:   time.Date(2024, time.January, 8, 11, 0, 0, 0, time.UTC),
+	}
+
+	now := time.Date(2024, time.January, 8, 8, 0, 0, 0, time.UTC) // Monday
+
+	spans := []TimeSpan{first, second}
+
+	wantTime := time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC) // first's span starts first
+
+	gotTime, gotOk := NextTransition(now, spans)
+	assert.True(t, gotOk)
+	assert.True(t, wantTime.Equal(gotTime), "want %v, got %v", wantTime, gotTime)
+}
+
+func TestNextTransition_aggregate_empty(t *testing.T) {
+	gotTime, gotOk := NextTransition(time.Now(), nil)
+	assert.False(t, gotOk)
+	assert.True(t, gotTime.IsZero())
+}
+
+func TestSleepDuration(t *testing.T) {
+	span := absoluteTimeSpan{
+		from: time.Date(2024, time.January, 8, 10, 0, 0, 0, time.UTC),
+		to:   time.Date(2024, time.January, 8, 11, 0, 0, 0, time.UTC),
+	}
+
+	now := time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC)
+	spans := []TimeSpan{span}
+
+	t.Run("wakes up at the transition when it is sooner than maxInterval", func(t *testing.T) {
+		gotWait := SleepDuration(now, spans, 2*time.Hour)
+		assert.Equal(t, time.Hour, gotWait)
+	})
+
+	t.Run("caps the wait at maxInterval when the transition is further away", func(t *testing.T) {
+		gotWait := SleepDuration(now, spans, 10*time.Minute)
+		assert.Equal(t, 10*time.Minute, gotWait)
+	})
+
+	t.Run("falls back to maxInterval with no spans", func(t *testing.T) {
+		gotWait := SleepDuration(now, nil, 5*time.Minute)
+		assert.Equal(t, 5*time.Minute, gotWait)
+	})
+}
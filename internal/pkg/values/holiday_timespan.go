@@ -0,0 +1,340 @@
+package values
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Fovty/GoKubeDownscaler/internal/pkg/ical"
+)
+
+// holidayMode controls how a holidayTimeSpan's matching windows are interpreted by whatever
+// consumes it: as forced uptime (e.g. a change freeze) or forced downtime (e.g. a public holiday).
+type holidayMode int
+
+const (
+	holidayDowntime holidayMode = iota
+	holidayUptime
+)
+
+// defaultFeedRefreshInterval is how often a holidayTimeSpan's feeds are refreshed if not configured otherwise.
+const defaultFeedRefreshInterval = time.Hour
+
+// maxFeedBackoff caps the exponential backoff between failed refresh attempts.
+const maxFeedBackoff = 30 * time.Minute
+
+// holidayTimeSpan is a TimeSpan backed by one or more iCalendar feeds, refreshed in the
+// background. Each VEVENT in the feeds becomes an absolute window; the timespan matches whenever
+// the checked time falls inside any of them.
+type holidayTimeSpan struct {
+	mode holidayMode
+	feed *icalFeed
+}
+
+// newHolidayTimeSpan creates a holidayTimeSpan backed by the given feed sources (file paths or
+// HTTP(S) URLs), refreshed every refreshEvery.
+func newHolidayTimeSpan(mode holidayMode, sources []string, refreshEvery time.Duration) *holidayTimeSpan {
+	if refreshEvery <= 0 {
+		refreshEvery = defaultFeedRefreshInterval
+	}
+
+	return &holidayTimeSpan{
+		mode: mode,
+		feed: newICalFeed(sources, refreshEvery),
+	}
+}
+
+// isTimeInSpan checks if timestamp falls within any of the feed's current events.
+func (t holidayTimeSpan) isTimeInSpan(timestamp time.Time) bool {
+	return t.feed.isTimeInSpan(timestamp)
+}
+
+// NextTransition finds the next time the holiday timespan starts or stops matching, by sampling
+// minute boundaries forward from now within a bounded lookahead window. Since the underlying feed
+// refreshes in the background, a transition returned here reflects the events loaded at call time
+// and may shift if the feed changes before then.
+func (t holidayTimeSpan) NextTransition(now time.Time) (time.Time, bool) {
+	return genericNextTransition(t, now)
+}
+
+// Start begins refreshing the timespan's feeds in the background until ctx is canceled.
+func (t *holidayTimeSpan) Start(ctx context.Context) {
+	t.feed.Start(ctx)
+}
+
+// Mode reports whether the timespan represents forced uptime (e.g. a change freeze) or forced
+// downtime (e.g. a public holiday), for a caller to branch on alongside isTimeInSpan.
+func (t holidayTimeSpan) Mode() holidayMode {
+	return t.mode
+}
+
+// Health reports the feed's refresh health.
+func (t *holidayTimeSpan) Health() *FeedHealth {
+	return t.feed.health
+}
+
+// FeedHealth tracks the outcome of a holidayTimeSpan's background feed refreshes, so it can be
+// surfaced as metrics.
+type FeedHealth struct {
+	successfulRefreshes atomic.Int64
+	failedRefreshes     atomic.Int64
+	lastRefresh         atomic.Int64 // unix nanos, 0 if never attempted
+	lastSuccess         atomic.Int64 // unix nanos, 0 if never succeeded
+}
+
+func (h *FeedHealth) record(err error) {
+	h.lastRefresh.Store(time.Now().UnixNano())
+
+	if err != nil {
+		h.failedRefreshes.Add(1)
+		return
+	}
+
+	h.successfulRefreshes.Add(1)
+	h.lastSuccess.Store(time.Now().UnixNano())
+}
+
+// SuccessfulRefreshes returns the number of refreshes that fetched and parsed every feed source successfully.
+func (h *FeedHealth) SuccessfulRefreshes() int64 {
+	return h.successfulRefreshes.Load()
+}
+
+// FailedRefreshes returns the number of refreshes where at least one feed source could not be fetched or parsed.
+func (h *FeedHealth) FailedRefreshes() int64 {
+	return h.failedRefreshes.Load()
+}
+
+// LastRefresh returns when the feed was last attempted to be refreshed, or the zero time if never.
+func (h *FeedHealth) LastRefresh() time.Time {
+	return unixNanoOrZero(h.lastRefresh.Load())
+}
+
+// LastSuccess returns when the feed was last refreshed successfully, or the zero time if never.
+func (h *FeedHealth) LastSuccess() time.Time {
+	return unixNanoOrZero(h.lastSuccess.Load())
+}
+
+func unixNanoOrZero(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, nanos)
+}
+
+// feedCacheEntry is the last successfully fetched state of one feed source, used to make
+// conditional HTTP requests and to keep serving stale data while a source is failing.
+type feedCacheEntry struct {
+	etag         string
+	lastModified string
+	events       []ical.Event
+}
+
+// icalFeed loads and periodically refreshes a set of iCalendar feed sources, merging their
+// events into a single, queryable window list.
+type icalFeed struct {
+	sources      []string
+	refreshEvery time.Duration
+	httpClient   *http.Client
+	health       *FeedHealth
+
+	eventsMu sync.RWMutex
+	events   []ical.Event
+
+	cacheMu sync.Mutex
+	cache   map[string]feedCacheEntry
+}
+
+// newICalFeed creates an icalFeed that has not yet been started or populated.
+func newICalFeed(sources []string, refreshEvery time.Duration) *icalFeed {
+	return &icalFeed{
+		sources:      sources,
+		refreshEvery: refreshEvery,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+		health:       &FeedHealth{},
+		cache:        make(map[string]feedCacheEntry),
+	}
+}
+
+// isTimeInSpan checks if timestamp falls within any currently loaded event.
+func (f *icalFeed) isTimeInSpan(timestamp time.Time) bool {
+	f.eventsMu.RLock()
+	defer f.eventsMu.RUnlock()
+
+	for _, event := range f.events {
+		if !timestamp.Before(event.Start) && timestamp.Before(event.End) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// earliestEventStart returns the start of the earliest currently loaded event, if any, so
+// sampledOverlap can anchor its sampling window at the feed's real active period instead of the
+// arbitrary default epoch.
+func (f *icalFeed) earliestEventStart() (time.Time, bool) {
+	f.eventsMu.RLock()
+	defer f.eventsMu.RUnlock()
+
+	var earliest time.Time
+
+	found := false
+
+	for _, event := range f.events {
+		if !found || event.Start.Before(earliest) {
+			earliest = event.Start
+			found = true
+		}
+	}
+
+	return earliest, found
+}
+
+// Start refreshes the feed once immediately, then keeps refreshing it every refreshEvery in the
+// background, backing off exponentially between attempts while a source is failing, until ctx is
+// canceled.
+func (f *icalFeed) Start(ctx context.Context) {
+	go f.run(ctx)
+}
+
+func (f *icalFeed) run(ctx context.Context) {
+	backoff := time.Second
+
+	for {
+		err := f.refresh()
+		f.health.record(err)
+
+		wait := f.refreshEvery
+		if err != nil {
+			wait = backoff
+
+			backoff *= 2
+			if backoff > maxFeedBackoff {
+				backoff = maxFeedBackoff
+			}
+		} else {
+			backoff = time.Second
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// refresh fetches every source, merging their events into the feed. If a source fails to fetch
+// or parse, its previously cached events are kept and the first error is returned, so a
+// transiently unreachable feed doesn't blank out the timespan.
+func (f *icalFeed) refresh() error {
+	merged := make([]ical.Event, 0, len(f.sources))
+
+	var firstErr error
+
+	for _, source := range f.sources {
+		events, err := f.fetchSource(source)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to refresh feed %q: %w", source, err)
+			}
+
+			events = f.cachedEvents(source)
+		}
+
+		merged = append(merged, events...)
+	}
+
+	f.eventsMu.Lock()
+	f.events = merged
+	f.eventsMu.Unlock()
+
+	return firstErr
+}
+
+func (f *icalFeed) cachedEvents(source string) []ical.Event {
+	f.cacheMu.Lock()
+	defer f.cacheMu.Unlock()
+
+	return f.cache[source].events
+}
+
+// fetchSource fetches and parses a single feed source, which is either a file path or an
+// HTTP(S) URL. HTTP sources are fetched conditionally using the cached ETag/Last-Modified, so an
+// unchanged feed doesn't need to be re-parsed.
+func (f *icalFeed) fetchSource(source string) ([]ical.Event, error) {
+	if !isHTTPSource(source) {
+		file, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open ics file: %w", err)
+		}
+		defer file.Close()
+
+		events, err := ical.Parse(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ics file: %w", err)
+		}
+
+		f.cacheMu.Lock()
+		f.cache[source] = feedCacheEntry{events: events}
+		f.cacheMu.Unlock()
+
+		return events, nil
+	}
+
+	f.cacheMu.Lock()
+	cached := f.cache[source]
+	f.cacheMu.Unlock()
+
+	req, err := http.NewRequest(http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	if cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	if cached.lastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.lastModified)
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return cached.events, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %q", resp.Status)
+	}
+
+	events, err := ical.Parse(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse feed: %w", err)
+	}
+
+	f.cacheMu.Lock()
+	f.cache[source] = feedCacheEntry{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		events:       events,
+	}
+	f.cacheMu.Unlock()
+
+	return events, nil
+}
+
+func isHTTPSource(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
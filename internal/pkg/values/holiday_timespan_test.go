@@ -0,0 +1,161 @@
+package values
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const cannedFeed = "BEGIN:VCALENDAR\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Christmas break\r\n" +
+	"DTSTART:20241224T000000Z\r\n" +
+	"DTEND:20241227T000000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestHolidayTimeSpan_isTimeInSpan_file(t *testing.T) {
+	feedPath := filepath.Join(t.TempDir(), "holidays.ics")
+	assert.NoError(t, os.WriteFile(feedPath, []byte(cannedFeed), 0o600))
+
+	span := newHolidayTimeSpan(holidayDowntime, []string{feedPath}, time.Hour)
+	assert.NoError(t, span.feed.refresh())
+
+	assert.True(t, span.isTimeInSpan(time.Date(2024, time.December, 25, 12, 0, 0, 0, time.UTC)))
+	assert.False(t, span.isTimeInSpan(time.Date(2024, time.December, 28, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestICalFeed_fetchSource_http(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(cannedFeed)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	feed := newICalFeed([]string{server.URL}, time.Hour)
+
+	assert.NoError(t, feed.refresh())
+	assert.True(t, feed.isTimeInSpan(time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC)))
+
+	// A second refresh should hit the server again but get a 304, reusing the cached events.
+	assert.NoError(t, feed.refresh())
+	assert.EqualValues(t, 2, requests.Load())
+	assert.True(t, feed.isTimeInSpan(time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestICalFeed_fetchSource_keepsStaleDataOnFailure(t *testing.T) {
+	var fail atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Write([]byte(cannedFeed)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	feed := newICalFeed([]string{server.URL}, time.Hour)
+	assert.NoError(t, feed.refresh())
+	assert.True(t, feed.isTimeInSpan(time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC)))
+
+	fail.Store(true)
+	assert.Error(t, feed.refresh())
+	assert.True(t, feed.isTimeInSpan(time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestICalFeed_health(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(cannedFeed)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	feed := newICalFeed([]string{server.URL}, time.Hour)
+
+	assert.Zero(t, feed.health.SuccessfulRefreshes())
+	assert.True(t, feed.health.LastRefresh().IsZero())
+
+	assert.NoError(t, feed.refresh())
+	feed.health.record(nil)
+
+	assert.EqualValues(t, 1, feed.health.SuccessfulRefreshes())
+	assert.False(t, feed.health.LastSuccess().IsZero())
+}
+
+func TestICalFeed_Start_backgroundRefresh(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(cannedFeed)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	feed := newICalFeed([]string{server.URL}, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	feed.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		return requests.Load() >= 2
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestHolidayTimeSpan_Mode(t *testing.T) {
+	feedPath := filepath.Join(t.TempDir(), "holidays.ics")
+	assert.NoError(t, os.WriteFile(feedPath, []byte(cannedFeed), 0o600))
+
+	downtime := newHolidayTimeSpan(holidayDowntime, []string{feedPath}, time.Hour)
+	assert.Equal(t, holidayDowntime, downtime.Mode())
+
+	uptime := newHolidayTimeSpan(holidayUptime, []string{feedPath}, time.Hour)
+	assert.Equal(t, holidayUptime, uptime.Mode())
+}
+
+// TestAreTimespanOverlapped_holiday guards against sampledOverlap silently returning false for a
+// holidayTimeSpan: its events are dated in the real current/future year, well past the default
+// sample epoch, so the window must re-base onto the feed's actual events.
+func TestAreTimespanOverlapped_holiday(t *testing.T) {
+	const futureFeed = "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:New Year break\r\n" +
+		"DTSTART:20260115T000000Z\r\n" +
+		"DTEND:20260117T000000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	feedPath := filepath.Join(t.TempDir(), "holidays.ics")
+	assert.NoError(t, os.WriteFile(feedPath, []byte(futureFeed), 0o600))
+
+	holiday := newHolidayTimeSpan(holidayDowntime, []string{feedPath}, time.Hour)
+	assert.NoError(t, holiday.feed.refresh())
+
+	alwaysOn := relativeTimeSpan{
+		timezone:    time.UTC,
+		weekdayFrom: time.Sunday,
+		weekdayTo:   time.Saturday,
+		timeFrom:    zeroTime,
+		timeTo:      zeroTime.Add(24 * time.Hour),
+	}
+
+	assert.True(t, areTimespanOverlapped(*holiday, alwaysOn))
+}
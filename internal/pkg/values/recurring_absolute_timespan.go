@@ -0,0 +1,240 @@
+package values
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rruleTimeLayout is the timestamp layout accepted by recurringAbsoluteTimeSpan, e.g.
+// "2024-12-24T00:00Z" or "2024-12-24T00:00+01:00".
+const rruleTimeLayout = "2006-01-02T15:04Z07:00"
+
+// recurrenceFrequency is how often a recurringAbsoluteTimeSpan's window repeats.
+type recurrenceFrequency int
+
+const (
+	// recurrenceNone means the window occurs exactly once, like a plain absoluteTimeSpan.
+	recurrenceNone recurrenceFrequency = iota
+	recurrenceDaily
+	recurrenceWeekly
+	recurrenceMonthly
+	recurrenceYearly
+)
+
+// recurringAbsoluteTimeSpan is a fixed [from, to) window that repeats on a schedule, in the style
+// of an iCalendar RRULE, e.g. "2024-12-24T00:00Z/2024-12-27T00:00Z;FREQ=YEARLY;COUNT=5" for the
+// winter holidays recurring every year.
+type recurringAbsoluteTimeSpan struct {
+	from     time.Time
+	to       time.Time
+	freq     recurrenceFrequency
+	interval int
+	count    int       // 0 means unbounded unless until is set
+	until    time.Time // zero means unbounded unless count is set
+	exdates  []time.Time
+}
+
+// parseRecurringAbsoluteTimeSpan parses a recurring absolute timespan of the form
+// "from/to[;FREQ=DAILY|WEEKLY|MONTHLY|YEARLY][;INTERVAL=n][;COUNT=n][;UNTIL=ts][;EXDATE=ts,ts,...]".
+func parseRecurringAbsoluteTimeSpan(timespanString string) (*recurringAbsoluteTimeSpan, error) {
+	parts := strings.Split(timespanString, ";")
+
+	fromString, toString, found := strings.Cut(parts[0], "/")
+	if !found {
+		return nil, fmt.Errorf("invalid timespan %q: expected 'from/to'", parts[0])
+	}
+
+	from, err := time.Parse(rruleTimeLayout, fromString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse from: %w", err)
+	}
+
+	to, err := time.Parse(rruleTimeLayout, toString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse to: %w", err)
+	}
+
+	span := &recurringAbsoluteTimeSpan{from: from, to: to, interval: 1}
+
+	for _, rule := range parts[1:] {
+		if err := span.addRulePart(rule); err != nil {
+			return nil, fmt.Errorf("failed to parse rule %q: %w", rule, err)
+		}
+	}
+
+	return span, nil
+}
+
+// addRulePart parses a single "KEY=VALUE" recurrence rule part and applies it to the span.
+func (s *recurringAbsoluteTimeSpan) addRulePart(rule string) error {
+	key, value, found := strings.Cut(rule, "=")
+	if !found {
+		return fmt.Errorf("expected 'KEY=VALUE'")
+	}
+
+	switch key {
+	case "FREQ":
+		freq, err := parseRecurrenceFrequency(value)
+		if err != nil {
+			return err
+		}
+
+		s.freq = freq
+	case "INTERVAL":
+		interval, err := strconv.Atoi(value)
+		if err != nil || interval <= 0 {
+			return fmt.Errorf("invalid interval %q", value)
+		}
+
+		s.interval = interval
+	case "COUNT":
+		count, err := strconv.Atoi(value)
+		if err != nil || count <= 0 {
+			return fmt.Errorf("invalid count %q", value)
+		}
+
+		s.count = count
+	case "UNTIL":
+		until, err := time.Parse(rruleTimeLayout, value)
+		if err != nil {
+			return fmt.Errorf("invalid until %q: %w", value, err)
+		}
+
+		s.until = until
+	case "EXDATE":
+		for _, exField := range strings.Split(value, ",") {
+			exdate, err := time.Parse(rruleTimeLayout, exField)
+			if err != nil {
+				return fmt.Errorf("invalid exdate %q: %w", exField, err)
+			}
+
+			s.exdates = append(s.exdates, exdate)
+		}
+	default:
+		return fmt.Errorf("unknown rule %q", key)
+	}
+
+	return nil
+}
+
+// parseRecurrenceFrequency parses a FREQ value.
+func parseRecurrenceFrequency(value string) (recurrenceFrequency, error) {
+	switch value {
+	case "DAILY":
+		return recurrenceDaily, nil
+	case "WEEKLY":
+		return recurrenceWeekly, nil
+	case "MONTHLY":
+		return recurrenceMonthly, nil
+	case "YEARLY":
+		return recurrenceYearly, nil
+	default:
+		return recurrenceNone, fmt.Errorf("invalid frequency %q", value)
+	}
+}
+
+// isTimeInSpan checks if timestamp falls within any valid occurrence of the window.
+func (s recurringAbsoluteTimeSpan) isTimeInSpan(timestamp time.Time) bool {
+	if timestamp.Before(s.from) {
+		return false
+	}
+
+	// The estimate can be off by one due to variable month/year lengths, so check its neighbors too.
+	estimate := s.estimateOccurrence(timestamp)
+
+	for k := estimate - 1; k <= estimate+1; k++ {
+		if k < 0 || !s.occurrenceAllowed(k) {
+			continue
+		}
+
+		from, to := s.occurrence(k)
+		if !timestamp.Before(from) && timestamp.Before(to) && !s.isExdate(from) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextTransition finds the next time the recurring window starts or stops matching, by sampling
+// minute boundaries forward from now within a bounded lookahead window.
+func (s recurringAbsoluteTimeSpan) NextTransition(now time.Time) (time.Time, bool) {
+	return genericNextTransition(s, now)
+}
+
+// occurrence returns the k-th occurrence's [from, to) window.
+func (s recurringAbsoluteTimeSpan) occurrence(k int) (from, to time.Time) {
+	switch s.freq {
+	case recurrenceNone:
+		return s.from, s.to
+	case recurrenceDaily:
+		offset := time.Duration(k*s.interval) * 24 * time.Hour
+		return s.from.Add(offset), s.to.Add(offset)
+	case recurrenceWeekly:
+		offset := time.Duration(k*s.interval*7) * 24 * time.Hour
+		return s.from.Add(offset), s.to.Add(offset)
+	case recurrenceMonthly:
+		months := k * s.interval
+		return s.from.AddDate(0, months, 0), s.to.AddDate(0, months, 0)
+	case recurrenceYearly:
+		years := k * s.interval
+		return s.from.AddDate(years, 0, 0), s.to.AddDate(years, 0, 0)
+	default:
+		return s.from, s.to
+	}
+}
+
+// estimateOccurrence estimates which occurrence index timestamp falls into.
+func (s recurringAbsoluteTimeSpan) estimateOccurrence(timestamp time.Time) int {
+	switch s.freq {
+	case recurrenceNone:
+		return 0
+	case recurrenceDaily:
+		days := int(timestamp.Sub(s.from).Hours() / 24)
+		return days / s.interval
+	case recurrenceWeekly:
+		days := int(timestamp.Sub(s.from).Hours() / 24)
+		return days / (7 * s.interval)
+	case recurrenceMonthly:
+		months := (timestamp.Year()-s.from.Year())*12 + int(timestamp.Month()) - int(s.from.Month())
+		return months / s.interval
+	case recurrenceYearly:
+		years := timestamp.Year() - s.from.Year()
+		return years / s.interval
+	default:
+		return 0
+	}
+}
+
+// occurrenceAllowed checks if the k-th occurrence exists, honoring count/until.
+func (s recurringAbsoluteTimeSpan) occurrenceAllowed(k int) bool {
+	if s.freq == recurrenceNone {
+		return k == 0
+	}
+
+	if s.count > 0 && k >= s.count {
+		return false
+	}
+
+	if !s.until.IsZero() {
+		from, _ := s.occurrence(k)
+		if from.After(s.until) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isExdate checks if an occurrence's start time is excluded via EXDATE.
+func (s recurringAbsoluteTimeSpan) isExdate(occurrenceFrom time.Time) bool {
+	for _, exdate := range s.exdates {
+		if exdate.Equal(occurrenceFrom) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,140 @@
+package values
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecurringAbsoluteTimeSpan_errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		timespanString string
+	}{
+		{name: "missing separator", timespanString: "2024-12-24T00:00Z;FREQ=YEARLY"},
+		{name: "invalid from", timespanString: "not-a-time/2024-12-27T00:00Z"},
+		{name: "invalid freq", timespanString: "2024-12-24T00:00Z/2024-12-27T00:00Z;FREQ=FORTNIGHTLY"},
+		{name: "invalid interval", timespanString: "2024-12-24T00:00Z/2024-12-27T00:00Z;INTERVAL=0"},
+		{name: "unknown rule", timespanString: "2024-12-24T00:00Z/2024-12-27T00:00Z;WAT=1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotResult, gotErr := parseRecurringAbsoluteTimeSpan(test.timespanString)
+			assert.Error(t, gotErr)
+			assert.Nil(t, gotResult)
+		})
+	}
+}
+
+func TestRecurringAbsoluteTimeSpan_isTimeInSpan(t *testing.T) {
+	span, err := parseRecurringAbsoluteTimeSpan("2024-12-24T00:00Z/2024-12-27T00:00Z;FREQ=YEARLY;COUNT=5")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		time       time.Time
+		wantResult bool
+	}{
+		{
+			name:       "within the base occurrence",
+			time:       time.Date(2024, time.December, 25, 12, 0, 0, 0, time.UTC),
+			wantResult: true,
+		},
+		{
+			name:       "within a later occurrence",
+			time:       time.Date(2027, time.December, 26, 0, 0, 0, 0, time.UTC),
+			wantResult: true,
+		},
+		{
+			name:       "outside the window entirely",
+			time:       time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC),
+			wantResult: false,
+		},
+		{
+			name:       "before count is exhausted",
+			time:       time.Date(2028, time.December, 25, 0, 0, 0, 0, time.UTC),
+			wantResult: true,
+		},
+		{
+			name:       "after count is exhausted",
+			time:       time.Date(2029, time.December, 25, 0, 0, 0, 0, time.UTC),
+			wantResult: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.wantResult, span.isTimeInSpan(test.time))
+		})
+	}
+}
+
+func TestRecurringAbsoluteTimeSpan_until(t *testing.T) {
+	span, err := parseRecurringAbsoluteTimeSpan(
+		"2024-01-01T00:00Z/2024-01-02T00:00Z;FREQ=MONTHLY;UNTIL=2024-06-01T00:00Z",
+	)
+	assert.NoError(t, err)
+
+	assert.True(t, span.isTimeInSpan(time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)))
+	assert.False(t, span.isTimeInSpan(time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestRecurringAbsoluteTimeSpan_exdate(t *testing.T) {
+	span, err := parseRecurringAbsoluteTimeSpan(
+		"2024-01-01T00:00Z/2024-01-02T00:00Z;FREQ=MONTHLY;COUNT=3;EXDATE=2024-02-01T00:00Z",
+	)
+	assert.NoError(t, err)
+
+	assert.True(t, span.isTimeInSpan(time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)))
+	assert.False(t, span.isTimeInSpan(time.Date(2024, time.February, 1, 12, 0, 0, 0, time.UTC)))
+	assert.True(t, span.isTimeInSpan(time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestAreTimespanOverlapped_recurringAbsolute(t *testing.T) {
+	holiday, err := parseRecurringAbsoluteTimeSpan("2024-12-24T00:00Z/2024-12-27T00:00Z;FREQ=YEARLY;COUNT=5")
+	assert.NoError(t, err)
+
+	weekdays := relativeTimeSpan{
+		timezone:    time.UTC,
+		weekdayFrom: time.Monday,
+		weekdayTo:   time.Friday,
+		timeFrom:    zeroTime.Add(8 * time.Hour),
+		timeTo:      zeroTime.Add(20 * time.Hour),
+	}
+
+	assert.True(t, areTimespanOverlapped(*holiday, weekdays))
+}
+
+// TestAreTimespanOverlapped_recurringAbsolute_farFuture guards against sampledOverlap silently
+// returning false when a recurring span is anchored well past the default sample epoch: the
+// sampling window must re-base onto the span's real anchor, not stay pinned to the epoch.
+func TestAreTimespanOverlapped_recurringAbsolute_farFuture(t *testing.T) {
+	holiday, err := parseRecurringAbsoluteTimeSpan("2030-06-01T00:00Z/2030-06-03T00:00Z;FREQ=YEARLY;COUNT=5")
+	assert.NoError(t, err)
+
+	alwaysOn := relativeTimeSpan{
+		timezone:    time.UTC,
+		weekdayFrom: time.Sunday,
+		weekdayTo:   time.Saturday,
+		timeFrom:    zeroTime,
+		timeTo:      zeroTime.Add(24 * time.Hour),
+	}
+
+	assert.True(t, areTimespanOverlapped(*holiday, alwaysOn))
+}
+
+// TestAreTimespanOverlapped_recurringAbsolute_twoFarApartAnchors guards against sampledOverlap
+// silently returning false when both spans are anchored, more than overlapSampleWindow apart: the
+// window must re-base onto the later anchor in full (both start and end), not just push start
+// forward while leaving end tied to the first anchor's window.
+func TestAreTimespanOverlapped_recurringAbsolute_twoFarApartAnchors(t *testing.T) {
+	early, err := parseRecurringAbsoluteTimeSpan("2020-06-01T00:00Z/2020-06-03T00:00Z;FREQ=YEARLY")
+	assert.NoError(t, err)
+
+	late, err := parseRecurringAbsoluteTimeSpan("2025-06-01T00:00Z/2025-06-03T00:00Z;FREQ=YEARLY")
+	assert.NoError(t, err)
+
+	assert.True(t, areTimespanOverlapped(*early, *late))
+}
@@ -0,0 +1,448 @@
+// Package values implements the configuration values used by the downscaler, including the
+// timespan grammar used to restrict scaling to specific times.
+package values
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const timeOfDayLayout = "15:04"
+
+// timeOfDayBase is the zero-value date that time-of-day values are anchored to, so that two
+// time.Time values can be compared purely on their time-of-day component.
+var timeOfDayBase = time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var weekdayNames = map[string]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
+// TimeSpan represents a span of time which can be checked against a point in time.
+type TimeSpan interface {
+	// isTimeInSpan checks if the given timestamp falls within the timespan.
+	isTimeInSpan(timestamp time.Time) bool
+	// NextTransition returns the next time after now at which isTimeInSpan's result would change,
+	// and whether such a transition exists (it may not, e.g. for a timespan that always matches).
+	NextTransition(now time.Time) (time.Time, bool)
+}
+
+// relativeTimeSpan is a recurring, weekly timespan, e.g. "Mon-Fri 08:00-16:00 Europe/Berlin".
+type relativeTimeSpan struct {
+	timezone    *time.Location
+	weekdayFrom time.Weekday
+	weekdayTo   time.Weekday
+	timeFrom    time.Time
+	timeTo      time.Time
+}
+
+// parseRelativeTimeSpan parses a relative timespan of the form "Weekday-Weekday HH:MM-HH:MM Timezone".
+func parseRelativeTimeSpan(timespanString string) (*relativeTimeSpan, error) {
+	fields := strings.Fields(timespanString)
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("invalid timespan %q: expected 3 space separated fields, got %d", timespanString, len(fields))
+	}
+
+	weekdayField, timeField, timezoneName := fields[0], fields[1], fields[2]
+
+	timezone, err := time.LoadLocation(timezoneName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse timezone: %w", err)
+	}
+
+	weekdayFrom, weekdayTo, err := parseWeekdaySpan(weekdayField)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse weekday span: %w", err)
+	}
+
+	timeFrom, timeTo, err := parseTimeOfDaySpan(timeField)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse time of day span: %w", err)
+	}
+
+	return &relativeTimeSpan{
+		timezone:    timezone,
+		weekdayFrom: weekdayFrom,
+		weekdayTo:   weekdayTo,
+		timeFrom:    timeFrom,
+		timeTo:      timeTo,
+	}, nil
+}
+
+// parseWeekdaySpan parses a weekday span of the form "Weekday-Weekday", e.g. "Mon-Fri".
+func parseWeekdaySpan(weekdaySpan string) (from, to time.Weekday, err error) {
+	parts := strings.Split(weekdaySpan, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid weekday span %q: expected format 'Weekday-Weekday'", weekdaySpan)
+	}
+
+	from, ok := weekdayNames[parts[0]]
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid weekday %q", parts[0])
+	}
+
+	to, ok = weekdayNames[parts[1]]
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid weekday %q", parts[1])
+	}
+
+	return from, to, nil
+}
+
+// parseTimeOfDaySpan parses a time of day span of the form "HH:MM-HH:MM".
+func parseTimeOfDaySpan(timeSpan string) (from, to time.Time, err error) {
+	parts := strings.Split(timeSpan, "-")
+	if len(parts) != 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time span %q: expected format 'HH:MM-HH:MM'", timeSpan)
+	}
+
+	from, err = time.Parse(timeOfDayLayout, parts[0])
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to parse start time: %w", err)
+	}
+
+	to, err = time.Parse(timeOfDayLayout, parts[1])
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to parse end time: %w", err)
+	}
+
+	return from, to, nil
+}
+
+// isWeekdayInRange checks if weekday falls within [weekdayFrom, weekdayTo], wrapping around the
+// week if weekdayFrom is after weekdayTo (e.g. Sat-Sun).
+func (t relativeTimeSpan) isWeekdayInRange(weekday time.Weekday) bool {
+	if t.weekdayFrom <= t.weekdayTo {
+		return weekday >= t.weekdayFrom && weekday <= t.weekdayTo
+	}
+
+	return weekday >= t.weekdayFrom || weekday <= t.weekdayTo
+}
+
+// isTimeOfDayInRange checks if timeOfDay falls within [timeFrom, timeTo), wrapping around
+// midnight if timeFrom is after timeTo (e.g. 20:00-06:00).
+func (t relativeTimeSpan) isTimeOfDayInRange(timeOfDay time.Time) bool {
+	if !t.timeFrom.After(t.timeTo) {
+		return !timeOfDay.Before(t.timeFrom) && timeOfDay.Before(t.timeTo)
+	}
+
+	return !timeOfDay.Before(t.timeFrom) || timeOfDay.Before(t.timeTo)
+}
+
+// isTimeInSpan checks if timestamp falls within the relative timespan, once converted to its configured timezone.
+func (t relativeTimeSpan) isTimeInSpan(timestamp time.Time) bool {
+	localTime := timestamp.In(t.timezone)
+	// timeFrom/timeTo are anchored in UTC, so the local wall-clock time of day is anchored there too.
+	timeOfDay := time.Date(0, time.January, 1, localTime.Hour(), localTime.Minute(), localTime.Second(), localTime.Nanosecond(), time.UTC)
+
+	return t.isWeekdayInRange(localTime.Weekday()) && t.isTimeOfDayInRange(timeOfDay)
+}
+
+// getTimeOfDay strips the date component of t, keeping only its time of day and location, so
+// that two timestamps can be compared purely on their time of day.
+func getTimeOfDay(t time.Time) time.Time {
+	return time.Date(0, time.January, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// nextTransitionLookahead bounds how many days ahead NextTransition walks to find a relative
+// timespan's next boundary; it covers more than a full week so every weekday's boundaries are seen.
+const nextTransitionLookahead = 9
+
+// NextTransition returns the next time after now at which the relative timespan starts or stops
+// matching, found by walking forward day by day in its timezone and checking each day's
+// timeFrom/timeTo boundaries, plus midnight itself: a weekday-range boundary only takes effect at
+// midnight, which coincides with a timeFrom/timeTo boundary unless the time-of-day range wraps
+// past midnight (e.g. "Mon-Fri 22:00-06:00"), in which case midnight needs its own candidate.
+// On DST days a boundary is taken both as elapsed time since midnight and as the wall-clock time,
+// so a repeated wall-clock time yields both occurrences, and each timezone offset change is a
+// candidate too, so a boundary inside a skipped hour resolves to the first instant after the gap.
+func (t relativeTimeSpan) NextTransition(now time.Time) (time.Time, bool) {
+	local := now.In(t.timezone)
+	dayStart := startOfDay(local)
+
+	candidates := make([]time.Time, 0, 6*nextTransitionLookahead)
+	for i := 0; i < nextTransitionLookahead; i++ {
+		day := dayStart.AddDate(0, 0, i)
+		year, month, date := day.Date()
+		candidates = append(candidates,
+			day,
+			day.Add(t.timeFrom.Sub(timeOfDayBase)),
+			day.Add(t.timeTo.Sub(timeOfDayBase)),
+			time.Date(year, month, date, t.timeFrom.Hour(), t.timeFrom.Minute(), 0, 0, day.Location()),
+			time.Date(year, month, date, t.timeTo.Hour(), t.timeTo.Minute(), 0, 0, day.Location()),
+		)
+
+		if _, zoneEnd := day.ZoneBounds(); !zoneEnd.IsZero() {
+			candidates = append(candidates, zoneEnd)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+
+	state := t.isTimeInSpan(now)
+
+	for _, candidate := range candidates {
+		if !candidate.After(now) {
+			continue
+		}
+
+		candidateState := t.isTimeInSpan(candidate)
+		if candidateState != state {
+			return candidate, true
+		}
+
+		state = candidateState
+	}
+
+	return time.Time{}, false
+}
+
+// absoluteTimeSpan is a fixed, one-off timespan between two points in time.
+type absoluteTimeSpan struct {
+	from time.Time
+	to   time.Time
+}
+
+// isTimeInSpan checks if timestamp falls within [from, to).
+func (t absoluteTimeSpan) isTimeInSpan(timestamp time.Time) bool {
+	return !timestamp.Before(t.from) && timestamp.Before(t.to)
+}
+
+// NextTransition returns from if now is still before the window, to if now is inside it, or no
+// transition if now is already past it.
+func (t absoluteTimeSpan) NextTransition(now time.Time) (time.Time, bool) {
+	if now.Before(t.from) {
+		return t.from, true
+	}
+
+	if now.Before(t.to) {
+		return t.to, true
+	}
+
+	return time.Time{}, false
+}
+
+// areTimespanOverlapped checks if two timespans can ever be in effect at the same time.
+func areTimespanOverlapped(span1, span2 TimeSpan) bool {
+	switch s1 := span1.(type) {
+	case relativeTimeSpan:
+		switch s2 := span2.(type) {
+		case relativeTimeSpan:
+			return relativeOverlapsRelative(s1, s2)
+		case absoluteTimeSpan:
+			return relativeOverlapsAbsolute(s1, s2)
+		}
+	case absoluteTimeSpan:
+		switch s2 := span2.(type) {
+		case relativeTimeSpan:
+			return relativeOverlapsAbsolute(s2, s1)
+		case absoluteTimeSpan:
+			return absoluteOverlapsAbsolute(s1, s2)
+		}
+	}
+
+	return sampledOverlap(span1, span2)
+}
+
+// overlapSampleWindow bounds how far ahead sampledOverlap looks for a coinciding instant.
+const overlapSampleWindow = 366 * 24 * time.Hour
+
+// overlapSampleStep is the resolution sampledOverlap checks at; it must divide a minute evenly so
+// that minute-granularity spans (e.g. cronTimeSpan) are never skipped over.
+const overlapSampleStep = time.Minute
+
+// overlapSampleEpoch anchors the sampling window for timespans that don't carry their own
+// absolute anchor (e.g. two recurring timespans being compared to each other).
+var overlapSampleEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// sampledOverlap checks if two timespans are ever both in effect, by sampling minute boundaries
+// across a bounded lookahead window and short-circuiting on the first match. It is the fallback
+// overlap check for any timespan type without a dedicated, exact implementation above.
+func sampledOverlap(span1, span2 TimeSpan) bool {
+	start, end := overlapSampleEpoch, overlapSampleEpoch.Add(overlapSampleWindow)
+	anchored := false
+
+	if from, to, ok := sampleAnchor(span1); ok {
+		start, end = anchorSampleWindow(start, end, anchored, from, to)
+		anchored = true
+	}
+
+	if from, to, ok := sampleAnchor(span2); ok {
+		start, end = anchorSampleWindow(start, end, anchored, from, to)
+		anchored = true
+	}
+
+	for t := start; t.Before(end); t = t.Add(overlapSampleStep) {
+		if span1.isTimeInSpan(t) && span2.isTimeInSpan(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sampleAnchor returns the real absolute window backing a span, if it has one (to is the zero
+// time if the span is unbounded on that side), so sampledOverlap can sample from the span's
+// actual active period instead of the arbitrary default epoch. ok is false for spans with no
+// absolute anchor (e.g. purely recurring-by-weekday or cron spans), which don't influence the
+// window at all.
+func sampleAnchor(span TimeSpan) (from, to time.Time, ok bool) {
+	switch s := span.(type) {
+	case absoluteTimeSpan:
+		return s.from, s.to, true
+	case recurringAbsoluteTimeSpan:
+		return s.from, time.Time{}, true
+	case holidayTimeSpan:
+		from, ok := s.feed.earliestEventStart()
+		return from, time.Time{}, ok
+	default:
+		return time.Time{}, time.Time{}, false
+	}
+}
+
+// anchorSampleWindow narrows [start, end) to account for a span anchored at a real from (and,
+// if bounded, to) time. The first anchor encountered re-bases the window at its from instead of
+// leaving it pinned to the unrelated, stale default epoch; a later anchor further ahead re-bases
+// it again, since otherwise end would stay tied to the first anchor's window and could end up
+// before the new, later start.
+func anchorSampleWindow(start, end time.Time, anchored bool, from, to time.Time) (time.Time, time.Time) {
+	if !anchored || from.After(start) {
+		start = from
+		end = from.Add(overlapSampleWindow)
+	}
+
+	if !to.IsZero() && to.Before(end) {
+		end = to
+	}
+
+	return start, end
+}
+
+// relativeOverlapsRelative checks if two relative timespans share any weekday and time of day.
+func relativeOverlapsRelative(a, b relativeTimeSpan) bool {
+	return weekdayRangesOverlap(a.weekdayFrom, a.weekdayTo, b.weekdayFrom, b.weekdayTo) &&
+		timeOfDayRangesOverlap(a.timeFrom, a.timeTo, b.timeFrom, b.timeTo)
+}
+
+// absoluteOverlapsAbsolute checks if two absolute timespans share any point in time.
+func absoluteOverlapsAbsolute(a, b absoluteTimeSpan) bool {
+	return a.from.Before(b.to) && b.from.Before(a.to)
+}
+
+// relativeOverlapsAbsolute checks if a relative timespan is ever in effect during an absolute
+// timespan, by walking the absolute span day by day in the relative span's timezone.
+func relativeOverlapsAbsolute(rel relativeTimeSpan, abs absoluteTimeSpan) bool {
+	timezone := rel.timezone
+	if timezone == nil {
+		timezone = time.UTC
+	}
+
+	from := abs.from.In(timezone)
+	to := abs.to.In(timezone)
+
+	for day := startOfDay(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !rel.isWeekdayInRange(day.Weekday()) {
+			continue
+		}
+
+		dayFrom := timeOfDayBase
+		dayTo := timeOfDayBase.Add(24 * time.Hour)
+
+		if sameDay(day, from) {
+			dayFrom = time.Date(0, time.January, 1, from.Hour(), from.Minute(), from.Second(), from.Nanosecond(), time.UTC)
+		}
+
+		if sameDay(day, to) {
+			dayTo = time.Date(0, time.January, 1, to.Hour(), to.Minute(), to.Second(), to.Nanosecond(), time.UTC)
+		}
+
+		if timeOfDayRangesOverlap(rel.timeFrom, rel.timeTo, dayFrom, dayTo) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// startOfDay returns midnight of the day t falls on, in t's location.
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// sameDay checks if a and b fall on the same calendar day.
+func sameDay(a, b time.Time) bool {
+	aYear, aMonth, aDay := a.Date()
+	bYear, bMonth, bDay := b.Date()
+
+	return aYear == bYear && aMonth == bMonth && aDay == bDay
+}
+
+// timeInterval is a non-wrapping [start, end) interval of time.
+type timeInterval struct {
+	start time.Time
+	end   time.Time
+}
+
+// splitTimeRange splits a possibly midnight-wrapping [from, to) time-of-day range into one or
+// two non-wrapping intervals.
+func splitTimeRange(from, to time.Time) []timeInterval {
+	if !from.After(to) {
+		return []timeInterval{{start: from, end: to}}
+	}
+
+	dayStart := timeOfDayBase
+	dayEnd := timeOfDayBase.Add(24 * time.Hour)
+
+	return []timeInterval{
+		{start: from, end: dayEnd},
+		{start: dayStart, end: to},
+	}
+}
+
+// timeOfDayRangesOverlap checks if two, possibly midnight-wrapping, time-of-day ranges overlap.
+func timeOfDayRangesOverlap(from1, to1, from2, to2 time.Time) bool {
+	for _, a := range splitTimeRange(from1, to1) {
+		for _, b := range splitTimeRange(from2, to2) {
+			if a.start.Before(b.end) && b.start.Before(a.end) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// weekdaysInRange expands a, possibly week-wrapping, weekday range into the set of weekdays it covers.
+func weekdaysInRange(from, to time.Weekday) []time.Weekday {
+	days := []time.Weekday{from}
+
+	for day := from; day != to; day = (day + 1) % 7 {
+		days = append(days, (day+1)%7)
+	}
+
+	return days
+}
+
+// weekdayRangesOverlap checks if two, possibly week-wrapping, weekday ranges share a weekday.
+func weekdayRangesOverlap(from1, to1, from2, to2 time.Weekday) bool {
+	covered := make(map[time.Weekday]struct{})
+	for _, day := range weekdaysInRange(from1, to1) {
+		covered[day] = struct{}{}
+	}
+
+	for _, day := range weekdaysInRange(from2, to2) {
+		if _, ok := covered[day]; ok {
+			return true
+		}
+	}
+
+	return false
+}
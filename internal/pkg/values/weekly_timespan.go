@@ -0,0 +1,191 @@
+package values
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// weekdayAbbreviations is the inverse of weekdayNames, used to render a weeklyTimeSpan back to
+// its string form.
+var weekdayAbbreviations = func() map[time.Weekday]string {
+	abbreviations := make(map[time.Weekday]string, len(weekdayNames))
+	for name, weekday := range weekdayNames {
+		abbreviations[weekday] = name
+	}
+
+	return abbreviations
+}()
+
+// dayRange is the [start, end) time-of-day window a weeklyTimeSpan is in effect on a given
+// weekday. end may exceed 24h to express a range that crosses midnight into the next day; a
+// zero-length range (start == end) never matches.
+type dayRange struct {
+	start time.Duration
+	end   time.Duration
+}
+
+// weeklyTimeSpan is a schedule with an independent time range per weekday, e.g.
+// "Mon=09:00-17:00;Tue=09:00-17:00;Fri=10:00-14:00 Europe/Berlin". Weekdays not mentioned have no
+// uptime on that day.
+type weeklyTimeSpan struct {
+	timezone *time.Location
+	days     [7]dayRange // index = time.Weekday
+}
+
+// parseWeeklyTimeSpan parses a weekly timespan of the form
+// "Weekday=HH:MM-HH:MM;Weekday=HH:MM-HH:MM;... Timezone".
+func parseWeeklyTimeSpan(timespanString string) (*weeklyTimeSpan, error) {
+	fields := strings.Fields(timespanString)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("invalid weekly timespan %q: expected 'days timezone'", timespanString)
+	}
+
+	daysField, timezoneName := fields[0], fields[1]
+
+	timezone, err := time.LoadLocation(timezoneName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse timezone: %w", err)
+	}
+
+	span := &weeklyTimeSpan{timezone: timezone}
+
+	for _, entry := range strings.Split(daysField, ";") {
+		if err := span.addDayEntry(entry); err != nil {
+			return nil, fmt.Errorf("failed to parse day entry %q: %w", entry, err)
+		}
+	}
+
+	return span, nil
+}
+
+// addDayEntry parses a single "Weekday=HH:MM-HH:MM" entry and stores its range.
+func (t *weeklyTimeSpan) addDayEntry(entry string) error {
+	dayName, rangeString, found := strings.Cut(entry, "=")
+	if !found {
+		return fmt.Errorf("expected 'Weekday=HH:MM-HH:MM'")
+	}
+
+	weekday, ok := weekdayNames[dayName]
+	if !ok {
+		return fmt.Errorf("invalid weekday %q", dayName)
+	}
+
+	startString, endString, found := strings.Cut(rangeString, "-")
+	if !found {
+		return fmt.Errorf("expected 'HH:MM-HH:MM'")
+	}
+
+	start, err := parseTimeOfDayDuration(startString)
+	if err != nil {
+		return fmt.Errorf("failed to parse start time: %w", err)
+	}
+
+	end, err := parseTimeOfDayDuration(endString)
+	if err != nil {
+		return fmt.Errorf("failed to parse end time: %w", err)
+	}
+
+	if end < start {
+		return fmt.Errorf("end time %q is before start time %q", endString, startString)
+	}
+
+	t.days[weekday] = dayRange{start: start, end: end}
+
+	return nil
+}
+
+// parseTimeOfDayDuration parses a "HH:MM" time of day into the duration since midnight. Hours may
+// go up to 24 (for a full-day range ending at midnight) or beyond (for a range crossing into the
+// next day).
+func parseTimeOfDayDuration(s string) (time.Duration, error) {
+	hoursString, minutesString, found := strings.Cut(s, ":")
+	if !found {
+		return 0, fmt.Errorf("invalid time of day %q: expected 'HH:MM'", s)
+	}
+
+	hours, err := strconv.Atoi(hoursString)
+	if err != nil || hours < 0 {
+		return 0, fmt.Errorf("invalid hour %q", hoursString)
+	}
+
+	minutes, err := strconv.Atoi(minutesString)
+	if err != nil || minutes < 0 || minutes > 59 {
+		return 0, fmt.Errorf("invalid minute %q", minutesString)
+	}
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
+
+// formatTimeOfDayDuration renders a time-of-day duration back into "HH:MM" form.
+func formatTimeOfDayDuration(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", d/time.Hour, (d%time.Hour)/time.Minute)
+}
+
+// isTimeInSpan checks if timestamp falls within its weekday's range, once converted to the
+// weeklyTimeSpan's timezone, also honoring a range that crossed over from the previous day.
+func (t weeklyTimeSpan) isTimeInSpan(timestamp time.Time) bool {
+	local := timestamp.In(t.timezone)
+	timeOfDay := time.Duration(local.Hour())*time.Hour +
+		time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second +
+		time.Duration(local.Nanosecond())
+
+	today := t.days[local.Weekday()]
+	if today.end > today.start && timeOfDay >= today.start && timeOfDay < today.end {
+		return true
+	}
+
+	yesterday := t.days[(local.Weekday()+6)%7]
+	if yesterday.end > 24*time.Hour && timeOfDay < yesterday.end-24*time.Hour {
+		return true
+	}
+
+	return false
+}
+
+// NextTransition finds the next time the weeklyTimeSpan starts or stops matching, by sampling
+// minute boundaries forward from now within a bounded lookahead window.
+func (t weeklyTimeSpan) NextTransition(now time.Time) (time.Time, bool) {
+	return genericNextTransition(t, now)
+}
+
+// String renders the weeklyTimeSpan back into its compact multi-day syntax.
+func (t weeklyTimeSpan) String() string {
+	var entries []string
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		day := t.days[weekday]
+		if day.end <= day.start {
+			continue
+		}
+
+		entries = append(entries, fmt.Sprintf(
+			"%s=%s-%s",
+			weekdayAbbreviations[weekday],
+			formatTimeOfDayDuration(day.start),
+			formatTimeOfDayDuration(day.end),
+		))
+	}
+
+	return fmt.Sprintf("%s %s", strings.Join(entries, ";"), t.timezone.String())
+}
+
+// MarshalText implements encoding.TextMarshaler, which both encoding/json and yaml.v3 use, so a
+// weeklyTimeSpan (de)serializes as its compact string form.
+func (t weeklyTimeSpan) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *weeklyTimeSpan) UnmarshalText(text []byte) error {
+	parsed, err := parseWeeklyTimeSpan(string(text))
+	if err != nil {
+		return err
+	}
+
+	*t = *parsed
+
+	return nil
+}
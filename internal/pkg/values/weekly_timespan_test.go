@@ -0,0 +1,132 @@
+package values
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWeeklyTimeSpan_errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		timespanString string
+	}{
+		{name: "missing timezone", timespanString: "Mon=09:00-17:00"},
+		{name: "invalid timezone", timespanString: "Mon=09:00-17:00 Invalid"},
+		{name: "invalid weekday", timespanString: "Xxx=09:00-17:00 UTC"},
+		{name: "missing range separator", timespanString: "Mon=09:0017:00 UTC"},
+		{name: "end before start", timespanString: "Mon=17:00-09:00 UTC"},
+		{name: "invalid minute", timespanString: "Mon=09:70-17:00 UTC"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotResult, gotErr := parseWeeklyTimeSpan(test.timespanString)
+			assert.Error(t, gotErr)
+			assert.Nil(t, gotResult)
+		})
+	}
+}
+
+func TestWeeklyTimeSpan_isTimeInSpan(t *testing.T) {
+	span, err := parseWeeklyTimeSpan("Mon=09:00-17:00;Tue=09:00-17:00;Fri=10:00-14:00 UTC")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		time       time.Time
+		wantResult bool
+	}{
+		{
+			name:       "within Monday's range",
+			time:       time.Date(2024, time.July, 22, 12, 0, 0, 0, time.UTC), // Monday
+			wantResult: true,
+		},
+		{
+			name:       "before Monday's range",
+			time:       time.Date(2024, time.July, 22, 8, 0, 0, 0, time.UTC),
+			wantResult: false,
+		},
+		{
+			name:       "on Monday's end boundary",
+			time:       time.Date(2024, time.July, 22, 17, 0, 0, 0, time.UTC),
+			wantResult: false,
+		},
+		{
+			name:       "within Friday's shorter range",
+			time:       time.Date(2024, time.July, 26, 11, 0, 0, 0, time.UTC), // Friday
+			wantResult: true,
+		},
+		{
+			name:       "Wednesday has no uptime",
+			time:       time.Date(2024, time.July, 24, 12, 0, 0, 0, time.UTC), // Wednesday
+			wantResult: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.wantResult, span.isTimeInSpan(test.time))
+		})
+	}
+}
+
+func TestWeeklyTimeSpan_fullDayRange(t *testing.T) {
+	span, err := parseWeeklyTimeSpan("Sat=00:00-24:00 UTC")
+	assert.NoError(t, err)
+
+	assert.True(t, span.isTimeInSpan(time.Date(2024, time.July, 20, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, span.isTimeInSpan(time.Date(2024, time.July, 20, 23, 59, 59, 0, time.UTC)))
+	assert.False(t, span.isTimeInSpan(time.Date(2024, time.July, 21, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestWeeklyTimeSpan_zeroLengthRangeNeverMatches(t *testing.T) {
+	span, err := parseWeeklyTimeSpan("Mon=09:00-09:00 UTC")
+	assert.NoError(t, err)
+
+	assert.False(t, span.isTimeInSpan(time.Date(2024, time.July, 22, 9, 0, 0, 0, time.UTC)))
+}
+
+func TestWeeklyTimeSpan_crossesMidnight(t *testing.T) {
+	span, err := parseWeeklyTimeSpan("Fri=22:00-26:00 UTC")
+	assert.NoError(t, err)
+
+	assert.True(t, span.isTimeInSpan(time.Date(2024, time.July, 26, 23, 0, 0, 0, time.UTC))) // Friday 23:00
+	assert.True(t, span.isTimeInSpan(time.Date(2024, time.July, 27, 1, 0, 0, 0, time.UTC)))  // Saturday 01:00
+	assert.False(t, span.isTimeInSpan(time.Date(2024, time.July, 27, 3, 0, 0, 0, time.UTC))) // Saturday 03:00
+}
+
+func TestWeeklyTimeSpan_textMarshalling(t *testing.T) {
+	original, err := parseWeeklyTimeSpan("Mon=09:00-17:00;Fri=10:00-14:00 UTC")
+	assert.NoError(t, err)
+
+	marshalled, err := json.Marshal(original)
+	assert.NoError(t, err)
+	assert.Equal(t, `"Mon=09:00-17:00;Fri=10:00-14:00 UTC"`, string(marshalled))
+
+	var roundTripped weeklyTimeSpan
+
+	assert.NoError(t, json.Unmarshal(marshalled, &roundTripped))
+	assert.Equal(t, *original, roundTripped)
+}
+
+func TestAreTimespanOverlapped_weekly(t *testing.T) {
+	weekdayMornings, err := parseWeeklyTimeSpan("Mon=07:00-09:00;Wed=07:00-09:00 UTC")
+	assert.NoError(t, err)
+
+	weekdayAfternoons, err := parseWeeklyTimeSpan("Mon=13:00-15:00;Wed=13:00-15:00 UTC")
+	assert.NoError(t, err)
+
+	weekdayEarly := relativeTimeSpan{
+		timezone:    time.UTC,
+		weekdayFrom: time.Monday,
+		weekdayTo:   time.Friday,
+		timeFrom:    zeroTime.Add(8 * time.Hour),
+		timeTo:      zeroTime.Add(9 * time.Hour),
+	}
+
+	assert.True(t, areTimespanOverlapped(*weekdayMornings, weekdayEarly))
+	assert.False(t, areTimespanOverlapped(*weekdayAfternoons, weekdayEarly))
+}